@@ -0,0 +1,72 @@
+// Package v1alpha1 contains the ClusterMaintenanceWindow API, a cluster-scoped CRD that lets an admin
+// suspend parts of the registration hub's reconciliation for one or more ManagedClusters during a planned
+// maintenance window.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaintenanceAction names a behavior requested for a cluster while a ClusterMaintenanceWindow targeting
+// it is active. The maintenance controller's IsActionActive reports whether an action is in effect; no
+// controller in this module calls it, so no action changes another controller's behavior.
+type MaintenanceAction string
+
+const (
+	// SuspendAddonWork requests that the addon status updater skip reconciliation for the cluster.
+	SuspendAddonWork MaintenanceAction = "SuspendAddonWork"
+	// DrainLeases requests that the cluster's lease be treated as expected-stale rather than unhealthy.
+	DrainLeases MaintenanceAction = "DrainLeases"
+	// BlockCSRApproval requests that the CSR approver short-circuit with a Denied condition.
+	BlockCSRApproval MaintenanceAction = "BlockCSRApproval"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterMaintenanceWindow declares a maintenance window for one or more ManagedClusters.
+type ClusterMaintenanceWindow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterMaintenanceWindowSpec   `json:"spec"`
+	Status ClusterMaintenanceWindowStatus `json:"status,omitempty"`
+}
+
+// ClusterMaintenanceWindowSpec is the desired state of a ClusterMaintenanceWindow.
+type ClusterMaintenanceWindowSpec struct {
+	// ManagedClusterNames lists the clusters this window applies to.
+	ManagedClusterNames []string `json:"managedClusterNames"`
+
+	// Schedule controls when the window is active.
+	Schedule MaintenanceSchedule `json:"schedule"`
+
+	// Actions lists the behaviors to adopt while the window is active.
+	Actions []MaintenanceAction `json:"actions"`
+}
+
+// MaintenanceSchedule is either an explicit RFC3339 [From, To) range, or a Cron expression. Only From/To
+// is evaluated: a window is active only when both are set, and Cron is ignored.
+type MaintenanceSchedule struct {
+	// Cron is a standard five-field cron expression. Evaluating Cron requires a cron parser that is not
+	// part of this module yet; until one is vendored, windows that only set Cron never become active --
+	// set From/To for now.
+	Cron string `json:"cron,omitempty"`
+
+	From *metav1.Time `json:"from,omitempty"`
+	To   *metav1.Time `json:"to,omitempty"`
+}
+
+// ClusterMaintenanceWindowStatus is the observed state of a ClusterMaintenanceWindow.
+type ClusterMaintenanceWindowStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterMaintenanceWindowList is a list of ClusterMaintenanceWindow.
+type ClusterMaintenanceWindowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterMaintenanceWindow `json:"items"`
+}
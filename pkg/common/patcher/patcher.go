@@ -0,0 +1,202 @@
+// Package patcher provides a generic, conflict-aware alternative to the
+// Get->DeepCopy->Update(Status) loops historically wrapped in
+// retry.RetryOnConflict across the hub controllers. A Patcher computes a
+// two-way JSON merge patch between an old and a new copy of an object and
+// applies it with a single client.Patch call, optionally against the
+// object's /status subresource, retrying on write conflicts. This package
+// intentionally doesn't attempt a three-way merge: callers always Get
+// immediately before mutating and patching, so there is no separate
+// "last-applied" base to diff against. On a conflict error the same patch
+// is resent; the object is not fetched again.
+package patcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+)
+
+// MergeCondition merges cond into *conditions by Type, preserving LastTransitionTime when Status is
+// unchanged. Callers with a `Conditions []metav1.Condition` status field should use this instead of
+// hand-rolling condition de-duplication, so every CRD's status conditions behave the same way.
+func MergeCondition(conditions *[]metav1.Condition, cond metav1.Condition) {
+	meta.SetStatusCondition(conditions, cond)
+}
+
+// Object is the minimal interface a resource must satisfy to be patched:
+// it is a runtime.Object with ObjectMeta accessors.
+type Object interface {
+	runtime.Object
+	metav1.Object
+}
+
+// PatchClient is satisfied by the generated per-resource clientset
+// interfaces (e.g. ManagedClusterInterface, ManagedClusterAddOnInterface).
+type PatchClient[T Object] interface {
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (T, error)
+}
+
+// Patcher patches objects of type T through client, using TStatus as the
+// status type carried by T (kept as a type parameter so callers get a
+// statically-typed Patcher per resource rather than passing interface{}).
+type Patcher[T Object, TStatus any] struct {
+	client   PatchClient[T]
+	recorder events.Recorder
+	kind     string
+}
+
+// NewPatcher returns a Patcher backed by client, e.g.
+//
+//	patcher.NewPatcher[*clusterv1.ManagedCluster, clusterv1.ManagedClusterStatus](
+//		clusterClient.ClusterV1().ManagedClusters())
+func NewPatcher[T Object, TStatus any](client PatchClient[T]) *Patcher[T, TStatus] {
+	return &Patcher[T, TStatus]{client: client}
+}
+
+// WithEventRecorder attaches recorder to the Patcher so every subsequent patch that actually changes the
+// object emits a structured event, identifying the resource by kind (e.g. "ManagedCluster"). It returns the
+// same Patcher so it can be chained onto NewPatcher.
+func (p *Patcher[T, TStatus]) WithEventRecorder(recorder events.Recorder, kind string) *Patcher[T, TStatus] {
+	p.recorder = recorder
+	p.kind = kind
+	return p
+}
+
+// PatchStatus computes a two-way merge patch between old and new and, if
+// it is non-empty, applies it against the /status subresource, resending it
+// on conflict errors. It returns whether a patch was sent.
+func (p *Patcher[T, TStatus]) PatchStatus(ctx context.Context, new, old T) (bool, error) {
+	return p.patch(ctx, new, old, "status")
+}
+
+// PatchSpec computes a two-way merge patch between old and new and applies
+// it to the main resource (no subresource). Used for metadata-only changes
+// such as finalizers or annotations.
+func (p *Patcher[T, TStatus]) PatchSpec(ctx context.Context, new, old T) (bool, error) {
+	return p.patch(ctx, new, old)
+}
+
+func (p *Patcher[T, TStatus]) patch(ctx context.Context, new, old T, subresources ...string) (bool, error) {
+	oldData, err := json.Marshal(old)
+	if err != nil {
+		return false, err
+	}
+
+	newData, err := json.Marshal(new)
+	if err != nil {
+		return false, err
+	}
+
+	patchBytes, err := jsonpatch.CreateMergePatch(oldData, newData)
+	if err != nil {
+		return false, err
+	}
+	if string(patchBytes) == "{}" {
+		return false, nil
+	}
+
+	err = retry.OnError(retry.DefaultBackoff, errors.IsConflict, func() error {
+		_, err := p.client.Patch(ctx, old.GetName(), types.MergePatchType, patchBytes, metav1.PatchOptions{}, subresources...)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	p.emitPatchedEvent(old.GetName(), subresources...)
+	return true, nil
+}
+
+// ApplyStatus performs a server-side apply of new's status subresource, using fieldManager as a stable
+// field manager so repeated applies from the same controller keep owning the same fields. Unlike
+// PatchStatus this always issues a request to the API server; it is the caller's job to skip calling it
+// when nothing changed.
+func (p *Patcher[T, TStatus]) ApplyStatus(ctx context.Context, new T, fieldManager string) (bool, error) {
+	data, err := json.Marshal(new)
+	if err != nil {
+		return false, err
+	}
+
+	force := true
+	err = retry.OnError(retry.DefaultBackoff, errors.IsConflict, func() error {
+		_, err := p.client.Patch(ctx, new.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+			FieldManager: fieldManager,
+			Force:        &force,
+		}, "status")
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	p.emitPatchedEvent(new.GetName(), "status")
+	return true, nil
+}
+
+// emitPatchedEvent records that name's subresources (or the main resource, if none given) were patched, if
+// an event recorder has been attached via WithEventRecorder.
+func (p *Patcher[T, TStatus]) emitPatchedEvent(name string, subresources ...string) {
+	if p.recorder == nil {
+		return
+	}
+	reason := fmt.Sprintf("%sPatched", p.kind)
+	message := fmt.Sprintf("Patched %s %q", p.kind, name)
+	if len(subresources) > 0 {
+		reason = fmt.Sprintf("%s%sPatched", p.kind, strings.Title(subresources[0]))
+		message = fmt.Sprintf("Patched %s %q %s", p.kind, name, subresources[0])
+	}
+	p.recorder.Eventf(reason, message)
+}
+
+// AddFinalizer patches obj to ensure each of finalizers is present, doing
+// nothing if they are all already set.
+func (p *Patcher[T, TStatus]) AddFinalizer(ctx context.Context, obj T, finalizers ...string) (bool, error) {
+	existing := sets.NewString(obj.GetFinalizers()...)
+	toAdd := false
+	for _, f := range finalizers {
+		if !existing.Has(f) {
+			existing.Insert(f)
+			toAdd = true
+		}
+	}
+	if !toAdd {
+		return false, nil
+	}
+
+	newObj := obj.DeepCopyObject().(T)
+	newObj.SetFinalizers(existing.List())
+	return p.PatchSpec(ctx, newObj, obj)
+}
+
+// RemoveFinalizer patches obj to ensure each of finalizers is absent, doing
+// nothing if none of them are set.
+func (p *Patcher[T, TStatus]) RemoveFinalizer(ctx context.Context, obj T, finalizers ...string) (bool, error) {
+	existing := sets.NewString(obj.GetFinalizers()...)
+	toRemove := false
+	for _, f := range finalizers {
+		if existing.Has(f) {
+			existing.Delete(f)
+			toRemove = true
+		}
+	}
+	if !toRemove {
+		return false, nil
+	}
+
+	newObj := obj.DeepCopyObject().(T)
+	newObj.SetFinalizers(existing.List())
+	return p.PatchSpec(ctx, newObj, obj)
+}
@@ -2,17 +2,21 @@ package helpers
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"net/url"
+	"os"
 	"path/filepath"
+	"time"
 
 	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
 	addonv1alpha1client "open-cluster-management.io/api/client/addon/clientset/versioned"
 	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
 	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"open-cluster-management.io/registration/pkg/common/patcher"
 	"open-cluster-management.io/registration/pkg/hub/managedcluster/bindata"
 
-	"github.com/openshift/api"
 	"github.com/openshift/library-go/pkg/assets"
 	"github.com/openshift/library-go/pkg/operator/events"
 	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
@@ -20,74 +24,58 @@ import (
 	errorhelpers "github.com/openshift/library-go/pkg/operator/v1helpers"
 
 	certificatesv1 "k8s.io/api/certificates/v1"
-	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/serializer"
-	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/yaml"
 )
 
-var (
-	genericScheme = runtime.NewScheme()
-	genericCodecs = serializer.NewCodecFactory(genericScheme)
-	genericCodec  = genericCodecs.UniversalDeserializer()
-)
-
-func init() {
-	utilruntime.Must(api.InstallKube(genericScheme))
-}
-
 type UpdateManagedClusterStatusFunc func(status *clusterv1.ManagedClusterStatus) error
 
 func UpdateManagedClusterStatus(
 	ctx context.Context,
 	client clusterclientset.Interface,
 	spokeClusterName string,
+	recorder events.Recorder,
 	updateFuncs ...UpdateManagedClusterStatusFunc) (*clusterv1.ManagedClusterStatus, bool, error) {
-	updated := false
-	var updatedManagedClusterStatus *clusterv1.ManagedClusterStatus
-
-	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		managedCluster, err := client.ClusterV1().ManagedClusters().Get(ctx, spokeClusterName, metav1.GetOptions{})
-		if err != nil {
-			return err
-		}
-		oldStatus := &managedCluster.Status
-
-		newStatus := oldStatus.DeepCopy()
-		for _, update := range updateFuncs {
-			if err := update(newStatus); err != nil {
-				return err
-			}
-		}
-		if equality.Semantic.DeepEqual(oldStatus, newStatus) {
-			// We return the newStatus which is a deep copy of oldStatus but with all update funcs applied.
-			updatedManagedClusterStatus = newStatus
-			return nil
-		}
+	managedCluster, err := client.ClusterV1().ManagedClusters().Get(ctx, spokeClusterName, metav1.GetOptions{})
+	if err != nil {
+		return nil, false, err
+	}
 
-		managedCluster.Status = *newStatus
-		updatedManagedCluster, err := client.ClusterV1().ManagedClusters().UpdateStatus(ctx, managedCluster, metav1.UpdateOptions{})
-		if err != nil {
-			return err
+	oldStatus := &managedCluster.Status
+	newManagedCluster := managedCluster.DeepCopy()
+	newStatus := &newManagedCluster.Status
+	for _, update := range updateFuncs {
+		if err := update(newStatus); err != nil {
+			return nil, false, err
 		}
-		updatedManagedClusterStatus = &updatedManagedCluster.Status
-		updated = err == nil
-		return err
-	})
+	}
+	if equality.Semantic.DeepEqual(oldStatus, newStatus) {
+		// We return the newStatus which is a deep copy of oldStatus but with all update funcs applied.
+		return newStatus, false, nil
+	}
 
-	return updatedManagedClusterStatus, updated, err
+	managedClusterPatcher := patcher.NewPatcher[
+		*clusterv1.ManagedCluster, clusterv1.ManagedClusterStatus](
+		client.ClusterV1().ManagedClusters()).WithEventRecorder(recorder, "ManagedCluster")
+	updated, err := managedClusterPatcher.PatchStatus(ctx, newManagedCluster, managedCluster)
+	return newStatus, updated, err
 }
 
 func UpdateManagedClusterConditionFn(cond metav1.Condition) UpdateManagedClusterStatusFunc {
 	return func(oldStatus *clusterv1.ManagedClusterStatus) error {
-		meta.SetStatusCondition(&oldStatus.Conditions, cond)
+		patcher.MergeCondition(&oldStatus.Conditions, cond)
 		return nil
 	}
 }
@@ -98,45 +86,36 @@ func UpdateManagedClusterAddOnStatus(
 	ctx context.Context,
 	client addonv1alpha1client.Interface,
 	addOnNamespace, addOnName string,
+	recorder events.Recorder,
 	updateFuncs ...UpdateManagedClusterAddOnStatusFunc) (*addonv1alpha1.ManagedClusterAddOnStatus, bool, error) {
-	updated := false
-	var updatedAddOnStatus *addonv1alpha1.ManagedClusterAddOnStatus
-
-	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		addOn, err := client.AddonV1alpha1().ManagedClusterAddOns(addOnNamespace).Get(ctx, addOnName, metav1.GetOptions{})
-		if err != nil {
-			return err
-		}
-		oldStatus := &addOn.Status
-
-		newStatus := oldStatus.DeepCopy()
-		for _, update := range updateFuncs {
-			if err := update(newStatus); err != nil {
-				return err
-			}
-		}
-		if equality.Semantic.DeepEqual(oldStatus, newStatus) {
-			// We return the newStatus which is a deep copy of oldStatus but with all update funcs applied.
-			updatedAddOnStatus = newStatus
-			return nil
-		}
+	addOn, err := client.AddonV1alpha1().ManagedClusterAddOns(addOnNamespace).Get(ctx, addOnName, metav1.GetOptions{})
+	if err != nil {
+		return nil, false, err
+	}
 
-		addOn.Status = *newStatus
-		updatedAddOn, err := client.AddonV1alpha1().ManagedClusterAddOns(addOnNamespace).UpdateStatus(ctx, addOn, metav1.UpdateOptions{})
-		if err != nil {
-			return err
+	oldStatus := &addOn.Status
+	newAddOn := addOn.DeepCopy()
+	newStatus := &newAddOn.Status
+	for _, update := range updateFuncs {
+		if err := update(newStatus); err != nil {
+			return nil, false, err
 		}
-		updatedAddOnStatus = &updatedAddOn.Status
-		updated = err == nil
-		return err
-	})
+	}
+	if equality.Semantic.DeepEqual(oldStatus, newStatus) {
+		// We return the newStatus which is a deep copy of oldStatus but with all update funcs applied.
+		return newStatus, false, nil
+	}
 
-	return updatedAddOnStatus, updated, err
+	addOnPatcher := patcher.NewPatcher[
+		*addonv1alpha1.ManagedClusterAddOn, addonv1alpha1.ManagedClusterAddOnStatus](
+		client.AddonV1alpha1().ManagedClusterAddOns(addOnNamespace)).WithEventRecorder(recorder, "ManagedClusterAddOn")
+	updated, err := addOnPatcher.PatchStatus(ctx, newAddOn, addOn)
+	return newStatus, updated, err
 }
 
 func UpdateManagedClusterAddOnStatusFn(cond metav1.Condition) UpdateManagedClusterAddOnStatusFunc {
 	return func(oldStatus *addonv1alpha1.ManagedClusterAddOnStatus) error {
-		meta.SetStatusCondition(&oldStatus.Conditions, cond)
+		patcher.MergeCondition(&oldStatus.Conditions, cond)
 		return nil
 	}
 }
@@ -154,6 +133,68 @@ func IsCSRInTerminalState(status *certificatesv1.CertificateSigningRequestStatus
 	return false
 }
 
+const (
+	// ManagedClusterAnnotationPreserveResourcesOnDeletion is the annotation fallback for hubs whose API
+	// server has not yet rolled out ManagedCluster.Spec.PreserveResourcesOnDeletion.
+	// ShouldPreserveResourcesOnDeletion reports whether it is set to "true" on a ManagedCluster. Passed as
+	// the preserve argument, that makes CleanUpManagedClusterManifests strip only the ManagedCluster
+	// ownerReference StampClusterOwnerMeta added instead of deleting, and makes the CleanUpGroupFrom*
+	// helpers leave bindings untouched. No deregister path in this module calls
+	// ShouldPreserveResourcesOnDeletion.
+	ManagedClusterAnnotationPreserveResourcesOnDeletion = "cluster.open-cluster-management.io/preserve-resources-on-deletion"
+
+	// ClusterLabel names the spoke a hub RBAC object was applied for, so cleanup can list by label instead
+	// of scanning every binding on the hub. StampClusterOwnerMeta sets it; the RBAC drift controller stamps
+	// it on the per-cluster ClusterRoles and ClusterRoleBindings it reconciles. Nothing in this module
+	// stamps it on RoleBindings.
+	ClusterLabel = "open-cluster-management.io/cluster"
+)
+
+// NewClusterOwnerReference builds the ownerReference stamped on cluster-scoped RBAC (ClusterRoleBindings)
+// applied for cluster, so Kubernetes GC cascades their deletion when the ManagedCluster is removed.
+func NewClusterOwnerReference(cluster *clusterv1.ManagedCluster) metav1.OwnerReference {
+	controller := false
+	return metav1.OwnerReference{
+		APIVersion: clusterv1.SchemeGroupVersion.String(),
+		Kind:       "ManagedCluster",
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+		Controller: &controller,
+	}
+}
+
+// StampClusterOwnerMeta labels obj with ClusterLabel=cluster.Name and, for cluster-scoped resources,
+// appends a NewClusterOwnerReference so Kubernetes GC can cascade deletion. It is idempotent.
+func StampClusterOwnerMeta(obj metav1.Object, cluster *clusterv1.ManagedCluster, clusterScoped bool) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[ClusterLabel] = cluster.Name
+	obj.SetLabels(labels)
+
+	if !clusterScoped {
+		return
+	}
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == "ManagedCluster" && ref.Name == cluster.Name {
+			return
+		}
+	}
+	obj.SetOwnerReferences(append(obj.GetOwnerReferences(), NewClusterOwnerReference(cluster)))
+}
+
+// ShouldPreserveResourcesOnDeletion returns whether hub-side artifacts for cluster should be preserved,
+// rather than deleted, when the cluster is removed or hook-detached.
+//
+// This only checks the annotation fallback, never a ManagedCluster.Spec.PreserveResourcesOnDeletion field:
+// the ManagedCluster type lives in the open-cluster-management.io/api module, which this checkout doesn't
+// vendor, so there is no spec field here to add or read. If that field lands upstream, this should check it
+// first and fall back to the annotation only for hubs running an older API server.
+func ShouldPreserveResourcesOnDeletion(cluster *clusterv1.ManagedCluster) bool {
+	return cluster.Annotations[ManagedClusterAnnotationPreserveResourcesOnDeletion] == "true"
+}
+
 // IsValidHTTPSURL validate whether a URL is https URL
 func IsValidHTTPSURL(serverURL string) bool {
 	if serverURL == "" {
@@ -172,60 +213,253 @@ func IsValidHTTPSURL(serverURL string) bool {
 	return true
 }
 
-// CleanUpManagedClusterManifests clean up managed cluster resources from its manifest files
+// LoadAndValidateBootstrapKubeconfig reads the kubeconfig at path and validates it with
+// ValidateBootstrapKubeconfig, so a misconfigured bootstrap-kubeconfig fails fast at spoke startup with an
+// actionable error instead of surfacing later as an opaque TLS handshake failure.
+func LoadAndValidateBootstrapKubeconfig(path string) error {
+	cfg, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to load bootstrap kubeconfig %q: %w", path, err)
+	}
+	return ValidateBootstrapKubeconfig(cfg)
+}
+
+// ValidateBootstrapKubeconfig validates that cfg's current context points at an HTTPS server backed by a
+// usable, unexpired CA bundle; only the first certificate in the bundle is checked for expiry, and a cluster
+// entry with no CA at all is accepted when it sets insecure-skip-tls-verify. It rejects a cluster entry that sets both CertificateAuthority and
+// CertificateAuthorityData, since clientcmd resolves CertificateAuthorityData first and silently ignores
+// CertificateAuthority in that case, which is almost always a mistake in a hand-written bootstrap
+// kubeconfig.
+func ValidateBootstrapKubeconfig(cfg *clientcmdapi.Config) error {
+	kubeContext, ok := cfg.Contexts[cfg.CurrentContext]
+	if !ok {
+		return fmt.Errorf("bootstrap kubeconfig has no context named %q", cfg.CurrentContext)
+	}
+	cluster, ok := cfg.Clusters[kubeContext.Cluster]
+	if !ok {
+		return fmt.Errorf("bootstrap kubeconfig has no cluster named %q", kubeContext.Cluster)
+	}
+
+	if !IsValidHTTPSURL(cluster.Server) {
+		return fmt.Errorf("bootstrap kubeconfig cluster %q server %q is not a valid https URL", kubeContext.Cluster, cluster.Server)
+	}
+
+	if len(cluster.CertificateAuthority) > 0 && len(cluster.CertificateAuthorityData) > 0 {
+		return fmt.Errorf("bootstrap kubeconfig cluster %q sets both certificate-authority and certificate-authority-data", kubeContext.Cluster)
+	}
+
+	caData := cluster.CertificateAuthorityData
+	if len(caData) == 0 && len(cluster.CertificateAuthority) > 0 {
+		data, err := os.ReadFile(cluster.CertificateAuthority)
+		if err != nil {
+			return fmt.Errorf("unable to read bootstrap kubeconfig certificate-authority %q: %w", cluster.CertificateAuthority, err)
+		}
+		caData = data
+	}
+	if len(caData) == 0 && !cluster.InsecureSkipTLSVerify {
+		return fmt.Errorf("bootstrap kubeconfig cluster %q has no certificate authority and does not set insecure-skip-tls-verify", kubeContext.Cluster)
+	}
+	if len(caData) == 0 {
+		return nil
+	}
+
+	pool := x509.NewCertPool()
+	if ok := pool.AppendCertsFromPEM(caData); !ok {
+		return fmt.Errorf("bootstrap kubeconfig cluster %q certificate authority is not valid PEM", kubeContext.Cluster)
+	}
+
+	block, _ := pem.Decode(caData)
+	if block == nil {
+		return fmt.Errorf("bootstrap kubeconfig cluster %q certificate authority is not valid PEM", kubeContext.Cluster)
+	}
+	caCert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("bootstrap kubeconfig cluster %q certificate authority cannot be parsed: %w", kubeContext.Cluster, err)
+	}
+	if time.Now().After(caCert.NotAfter) {
+		return fmt.Errorf("bootstrap kubeconfig cluster %q certificate authority expired at %s", kubeContext.Cluster, caCert.NotAfter)
+	}
+
+	// Verifying the CA actually covers the server hostname requires dialing it (a bare CA certificate has
+	// no hostnames of its own to check against), which this helper intentionally does not do: it is meant
+	// to fail fast on obviously-broken kubeconfigs, not to replace the TLS handshake the spoke performs
+	// when it first talks to the hub.
+	return nil
+}
+
+// NewManagedClusterCleanupClients builds the dynamic client and RESTMapper CleanUpManagedClusterManifests
+// needs to delete (or strip annotations from) arbitrary manifest kinds without a hard-coded type switch.
+func NewManagedClusterCleanupClients(config *rest.Config) (dynamic.Interface, meta.RESTMapper, error) {
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dynamicClient, restmapper.NewDiscoveryRESTMapper(groupResources), nil
+}
+
+// CleanUpManagedClusterManifests clean up managed cluster resources from its manifest files. Each manifest
+// is decoded into an Unstructured and routed through mapper/dynamicClient, so any kind can be deleted
+// without a corresponding case in this function. When preserve is true, nothing is deleted: only the
+// ManagedCluster ownerReference StampClusterOwnerMeta added is stripped from each resource, so Kubernetes'
+// garbage collector no longer cascades its deletion once the ManagedCluster itself is removed, and the
+// resource remains intact for forensic/audit use or re-adoption.
 func CleanUpManagedClusterManifests(
 	ctx context.Context,
-	client kubernetes.Interface,
+	dynamicClient dynamic.Interface,
+	mapper meta.RESTMapper,
 	recorder events.Recorder,
+	preserve bool,
 	assetFunc resourceapply.AssetFunc,
 	files ...string) error {
+	if preserve {
+		return stripClusterOwnerReferences(ctx, dynamicClient, mapper, recorder, assetFunc, files...)
+	}
+
 	errs := []error{}
 	for _, file := range files {
-		objectRaw, err := assetFunc(file)
+		object, resource, err := decodeManifestResource(dynamicClient, mapper, assetFunc, file)
 		if err != nil {
 			errs = append(errs, err)
 			continue
 		}
-		object, _, err := genericCodec.Decode(objectRaw, nil, nil)
+		err = resource.Delete(ctx, object.GetName(), metav1.DeleteOptions{})
+		if errors.IsNotFound(err) {
+			continue
+		}
 		if err != nil {
 			errs = append(errs, err)
 			continue
 		}
-		switch t := object.(type) {
-		case *corev1.Namespace:
-			err = client.CoreV1().Namespaces().Delete(ctx, t.Name, metav1.DeleteOptions{})
-		case *rbacv1.Role:
-			err = client.RbacV1().Roles(t.Namespace).Delete(ctx, t.Name, metav1.DeleteOptions{})
-		case *rbacv1.RoleBinding:
-			err = client.RbacV1().RoleBindings(t.Namespace).Delete(ctx, t.Name, metav1.DeleteOptions{})
-		case *rbacv1.ClusterRole:
-			err = client.RbacV1().ClusterRoles().Delete(ctx, t.Name, metav1.DeleteOptions{})
-		case *rbacv1.ClusterRoleBinding:
-			err = client.RbacV1().ClusterRoleBindings().Delete(ctx, t.Name, metav1.DeleteOptions{})
-		default:
-			err = fmt.Errorf("unhandled type %T", object)
+		gvk := object.GroupVersionKind()
+		recorder.Eventf(fmt.Sprintf("ManagedCluster%sDeleted", gvk.Kind), "Deleted %s", resourcehelper.FormatResourceForCLIWithNamespace(object))
+	}
+	return errorhelpers.NewMultiLineAggregate(errs)
+}
+
+// stripClusterOwnerReferences removes the ManagedCluster ownerReference from each manifest's live object
+// instead of deleting it, preserving the resource itself while disarming the owner reference that would
+// otherwise let Kubernetes' garbage collector cascade its deletion from the ManagedCluster.
+func stripClusterOwnerReferences(
+	ctx context.Context,
+	dynamicClient dynamic.Interface,
+	mapper meta.RESTMapper,
+	recorder events.Recorder,
+	assetFunc resourceapply.AssetFunc,
+	files ...string) error {
+	errs := []error{}
+	for _, file := range files {
+		object, resource, err := decodeManifestResource(dynamicClient, mapper, assetFunc, file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
 		}
-		if errors.IsNotFound(err) {
+
+		stripErr := stripOwnerReference(
+			func() (metav1.Object, error) { return resource.Get(ctx, object.GetName(), metav1.GetOptions{}) },
+			func(obj metav1.Object) error {
+				_, err := resource.Update(ctx, obj.(*unstructured.Unstructured), metav1.UpdateOptions{})
+				return err
+			})
+		if errors.IsNotFound(stripErr) {
 			continue
 		}
-		if err != nil {
-			errs = append(errs, err)
+		if stripErr != nil {
+			errs = append(errs, stripErr)
 			continue
 		}
-		gvk := resourcehelper.GuessObjectGroupVersionKind(object)
-		recorder.Eventf(fmt.Sprintf("ManagedCluster%sDeleted", gvk.Kind), "Deleted %s", resourcehelper.FormatResourceForCLIWithNamespace(object))
+		gvk := object.GroupVersionKind()
+		recorder.Eventf(fmt.Sprintf("ManagedCluster%sPreserved", gvk.Kind), "Preserved %s", resourcehelper.FormatResourceForCLIWithNamespace(object))
 	}
 	return errorhelpers.NewMultiLineAggregate(errs)
 }
 
-// CleanUpGroupFromClusterRoleBindings search all clusterrolebindings for managed cluster group and remove the subject entry
-// or delete the clusterrolebinding if it's the only subject.
+// decodeManifestResource decodes the manifest at file into an Unstructured and resolves the
+// dynamic.ResourceInterface it should be fetched, updated or deleted through, using mapper to translate its
+// GroupVersionKind into a REST mapping and resource scope.
+func decodeManifestResource(
+	dynamicClient dynamic.Interface,
+	mapper meta.RESTMapper,
+	assetFunc resourceapply.AssetFunc,
+	file string) (*unstructured.Unstructured, dynamic.ResourceInterface, error) {
+	objectRaw, err := assetFunc(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	object := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(objectRaw, &object.Object); err != nil {
+		return nil, nil, err
+	}
+
+	gvk := object.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("no REST mapping for %s: %w", gvk.String(), err)
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return object, dynamicClient.Resource(mapping.Resource).Namespace(object.GetNamespace()), nil
+	}
+	return object, dynamicClient.Resource(mapping.Resource), nil
+}
+
+// stripOwnerReference removes the ManagedCluster ownerReference (see NewClusterOwnerReference) from the
+// object returned by get, calling update only if such a reference was actually present.
+func stripOwnerReference(get func() (metav1.Object, error), update func(metav1.Object) error) error {
+	obj, err := get()
+	if err != nil {
+		return err
+	}
+	refs := obj.GetOwnerReferences()
+	kept := make([]metav1.OwnerReference, 0, len(refs))
+	stripped := false
+	for _, ref := range refs {
+		if ref.Kind == "ManagedCluster" {
+			stripped = true
+			continue
+		}
+		kept = append(kept, ref)
+	}
+	if !stripped {
+		return nil
+	}
+	obj.SetOwnerReferences(kept)
+	return update(obj)
+}
+
+// CleanUpGroupFromClusterRoleBindings finds the clusterrolebindings applied for clusterName and removes the
+// managedClusterGroup subject entry, or deletes the clusterrolebinding if it's the only subject. When
+// preserve is true this is a no-op, leaving the bindings and their subjects intact.
+//
+// By default this lists by ClusterLabel, which the RBAC drift controller stamps on the per-cluster
+// ClusterRoleBindings it reconciles (see StampClusterOwnerMeta), instead of scanning every
+// ClusterRoleBinding on the hub. Set
+// legacySweep to true to fall back to the pre-label-selector behaviour for clusters registered before the
+// label rolled out; this path is scheduled for removal in a following release.
 func CleanUpGroupFromClusterRoleBindings(
 	ctx context.Context,
 	client kubernetes.Interface,
 	recorder events.Recorder,
+	preserve bool,
+	legacySweep bool,
+	clusterName string,
 	managedClusterGroup string) error {
-	clusterRoleBindings, err := client.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if preserve {
+		return nil
+	}
+
+	listOptions := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", ClusterLabel, clusterName)}
+	if legacySweep {
+		listOptions = metav1.ListOptions{}
+	}
+	clusterRoleBindings, err := client.RbacV1().ClusterRoleBindings().List(ctx, listOptions)
 	if err != nil {
 		return err
 	}
@@ -263,14 +497,33 @@ func CleanUpGroupFromClusterRoleBindings(
 	return nil
 }
 
-// CleanUpGroupFromRoleBindings search all rolebindings for managed cluster group and remove the subject entry
-// or delete the rolebinding if it's the only subject.
+// CleanUpGroupFromRoleBindings finds the rolebindings applied for clusterName and removes the
+// managedClusterGroup subject entry, or deletes the rolebinding if it's the only subject. When preserve is
+// true this is a no-op, leaving the bindings and their subjects intact.
+//
+// By default this lists by ClusterLabel instead of scanning every RoleBinding in every namespace on the
+// hub. Nothing in this module stamps ClusterLabel on RoleBindings (StampClusterOwnerMeta is applied to
+// ClusterRoles and ClusterRoleBindings only), so the default path matches only RoleBindings labeled by
+// other means. Set
+// legacySweep to true to fall back to the pre-label-selector behaviour for clusters registered before the
+// label rolled out; this path is scheduled for removal in a following release.
 func CleanUpGroupFromRoleBindings(
 	ctx context.Context,
 	client kubernetes.Interface,
 	recorder events.Recorder,
+	preserve bool,
+	legacySweep bool,
+	clusterName string,
 	managedClusterGroup string) error {
-	roleBindings, err := client.RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if preserve {
+		return nil
+	}
+
+	listOptions := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", ClusterLabel, clusterName)}
+	if legacySweep {
+		listOptions = metav1.ListOptions{}
+	}
+	roleBindings, err := client.RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, listOptions)
 	if err != nil {
 		return err
 	}
@@ -2,8 +2,14 @@ package helpers
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -16,14 +22,18 @@ import (
 
 	"github.com/openshift/library-go/pkg/operator/events/eventstesting"
 
-	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/diff"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
 	fakekube "k8s.io/client-go/kubernetes/fake"
 	clienttesting "k8s.io/client-go/testing"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 const testManagedClusterGroup = "system:open-cluster-management:testgroup"
@@ -100,6 +110,7 @@ func TestUpdateStatusCondition(t *testing.T) {
 				context.TODO(),
 				fakeClusterClient,
 				"testspokecluster",
+				eventstesting.NewTestingEventRecorder(t),
 				UpdateManagedClusterConditionFn(c.newCondition),
 			)
 			if err != nil {
@@ -194,6 +205,7 @@ func TestUpdateManagedClusterAddOnStatus(t *testing.T) {
 				context.TODO(),
 				fakeAddOnClient,
 				"test", "test",
+				eventstesting.NewTestingEventRecorder(t),
 				UpdateManagedClusterAddOnStatusFn(c.newCondition),
 			)
 			if err != nil {
@@ -254,6 +266,118 @@ func TestIsValidHTTPSURL(t *testing.T) {
 	}
 }
 
+// newTestCAPEM returns a self-signed CA certificate, PEM-encoded, expiring at notAfter.
+func newTestCAPEM(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func newTestBootstrapKubeconfig(server string, caData []byte, caFile string, insecure bool) *clientcmdapi.Config {
+	return &clientcmdapi.Config{
+		CurrentContext: "default",
+		Contexts: map[string]*clientcmdapi.Context{
+			"default": {Cluster: "default"},
+		},
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"default": {
+				Server:                   server,
+				CertificateAuthorityData: caData,
+				CertificateAuthority:     caFile,
+				InsecureSkipTLSVerify:    insecure,
+			},
+		},
+	}
+}
+
+func TestValidateBootstrapKubeconfig(t *testing.T) {
+	validCA := newTestCAPEM(t, time.Now().Add(24*time.Hour))
+	expiredCA := newTestCAPEM(t, time.Now().Add(-24*time.Hour))
+
+	cases := []struct {
+		name        string
+		cfg         *clientcmdapi.Config
+		expectedErr string
+	}{
+		{
+			name: "valid https server with a valid CA",
+			cfg:  newTestBootstrapKubeconfig("https://127.0.0.1:6443", validCA, "", false),
+		},
+		{
+			name:        "http server is rejected",
+			cfg:         newTestBootstrapKubeconfig("http://127.0.0.1:6443", validCA, "", false),
+			expectedErr: "is not a valid https URL",
+		},
+		{
+			name:        "both certificate-authority and certificate-authority-data set",
+			cfg:         newTestBootstrapKubeconfig("https://127.0.0.1:6443", validCA, "/etc/ca.crt", false),
+			expectedErr: "sets both certificate-authority and certificate-authority-data",
+		},
+		{
+			name:        "no CA and insecure-skip-tls-verify unset",
+			cfg:         newTestBootstrapKubeconfig("https://127.0.0.1:6443", nil, "", false),
+			expectedErr: "does not set insecure-skip-tls-verify",
+		},
+		{
+			name: "no CA but insecure-skip-tls-verify is set",
+			cfg:  newTestBootstrapKubeconfig("https://127.0.0.1:6443", nil, "", true),
+		},
+		{
+			name:        "CA data is not valid PEM",
+			cfg:         newTestBootstrapKubeconfig("https://127.0.0.1:6443", []byte("not a cert"), "", false),
+			expectedErr: "is not valid PEM",
+		},
+		{
+			name:        "CA has expired",
+			cfg:         newTestBootstrapKubeconfig("https://127.0.0.1:6443", expiredCA, "", false),
+			expectedErr: "certificate authority expired",
+		},
+		{
+			name:        "current context is missing",
+			cfg:         &clientcmdapi.Config{CurrentContext: "missing"},
+			expectedErr: "no context named",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateBootstrapKubeconfig(c.cfg)
+			testinghelpers.AssertError(t, err, c.expectedErr)
+		})
+	}
+}
+
+// newTestRESTMapper returns a RESTMapper covering the kinds exercised by TestCleanUpManagedClusterManifests,
+// standing in for the discovery-backed mapper NewManagedClusterCleanupClients builds in production.
+func newTestRESTMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRoleBinding"}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}, meta.RESTScopeRoot)
+	return mapper
+}
+
 func TestCleanUpManagedClusterManifests(t *testing.T) {
 	applyFiles := map[string]runtime.Object{
 		"namespace":          testinghelpers.NewUnstructuredObj("v1", "Namespace", "", "n1"),
@@ -261,28 +385,42 @@ func TestCleanUpManagedClusterManifests(t *testing.T) {
 		"clusterrolebinding": testinghelpers.NewUnstructuredObj("rbac.authorization.k8s.io/v1", "ClusterRoleBinding", "", "crb1"),
 		"role":               testinghelpers.NewUnstructuredObj("rbac.authorization.k8s.io/v1", "Role", "n1", "r1"),
 		"rolebinding":        testinghelpers.NewUnstructuredObj("rbac.authorization.k8s.io/v1", "RoleBinding", "n1", "rb1"),
+		// arbitrary kinds are deleted through the same generic path, with no corresponding code change.
+		"secret":     testinghelpers.NewUnstructuredObj("v1", "Secret", "n1", "s1"),
+		"deployment": testinghelpers.NewUnstructuredObj("apps/v1", "Deployment", "n1", "d1"),
+		"crd":        testinghelpers.NewUnstructuredObj("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "crd1"),
+	}
+	applyObjects := []runtime.Object{
+		testinghelpers.NewUnstructuredObj("v1", "Namespace", "", "n1"),
+		testinghelpers.NewUnstructuredObj("rbac.authorization.k8s.io/v1", "ClusterRole", "", "cr1"),
+		testinghelpers.NewUnstructuredObj("rbac.authorization.k8s.io/v1", "ClusterRoleBinding", "", "crb1"),
+		testinghelpers.NewUnstructuredObj("rbac.authorization.k8s.io/v1", "Role", "n1", "r1"),
+		testinghelpers.NewUnstructuredObj("rbac.authorization.k8s.io/v1", "RoleBinding", "n1", "rb1"),
+		testinghelpers.NewUnstructuredObj("v1", "Secret", "n1", "s1"),
+		testinghelpers.NewUnstructuredObj("apps/v1", "Deployment", "n1", "d1"),
+		testinghelpers.NewUnstructuredObj("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "crd1"),
 	}
 	expectedActions := []string{}
 	for i := 0; i < len(applyFiles); i++ {
 		expectedActions = append(expectedActions, "delete")
 	}
+	preservedNamespace := testinghelpers.NewUnstructuredObj("v1", "Namespace", "", "n1")
+	preservedNamespace.(*unstructured.Unstructured).SetOwnerReferences([]metav1.OwnerReference{
+		{Kind: "ManagedCluster", Name: "cluster1"},
+	})
+
 	cases := []struct {
 		name            string
+		preserve        bool
 		applyObject     []runtime.Object
 		applyFiles      map[string]runtime.Object
 		validateActions func(t *testing.T, actions []clienttesting.Action)
 		expectedErr     string
 	}{
 		{
-			name: "delete applied objects",
-			applyObject: []runtime.Object{
-				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "n1"}},
-				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "cr1"}},
-				&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "crb1"}},
-				&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "n1"}},
-				&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "rb1", Namespace: "n1"}},
-			},
-			applyFiles: applyFiles,
+			name:        "delete applied objects of arbitrary kinds",
+			applyObject: applyObjects,
+			applyFiles:  applyFiles,
 			validateActions: func(t *testing.T, actions []clienttesting.Action) {
 				testinghelpers.AssertActions(t, actions, expectedActions...)
 			},
@@ -296,20 +434,34 @@ func TestCleanUpManagedClusterManifests(t *testing.T) {
 			},
 		},
 		{
-			name:            "unhandled types",
-			applyObject:     []runtime.Object{},
-			applyFiles:      map[string]runtime.Object{"secret": testinghelpers.NewUnstructuredObj("v1", "Secret", "n1", "s1")},
-			expectedErr:     "unhandled type *v1.Secret",
-			validateActions: testinghelpers.AssertNoActions,
+			name:     "preserve resources on deletion strips the ManagedCluster owner reference instead of deleting",
+			preserve: true,
+			applyObject: []runtime.Object{
+				preservedNamespace,
+			},
+			applyFiles: map[string]runtime.Object{
+				"namespace": testinghelpers.NewUnstructuredObj("v1", "Namespace", "", "n1"),
+			},
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "get", "update")
+				ns := actions[1].(clienttesting.UpdateActionImpl).Object.(*unstructured.Unstructured)
+				for _, ref := range ns.GetOwnerReferences() {
+					if ref.Kind == "ManagedCluster" {
+						t.Errorf("expected ManagedCluster owner reference to be stripped, got %v", ns.GetOwnerReferences())
+					}
+				}
+			},
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			kubeClient := fakekube.NewSimpleClientset(c.applyObject...)
+			dynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), c.applyObject...)
 			cleanUpErr := CleanUpManagedClusterManifests(
 				context.TODO(),
-				kubeClient,
+				dynamicClient,
+				newTestRESTMapper(),
 				eventstesting.NewTestingEventRecorder(t),
+				c.preserve,
 				func(name string) ([]byte, error) {
 					if c.applyFiles[name] == nil {
 						return nil, fmt.Errorf("Failed to find file")
@@ -319,7 +471,7 @@ func TestCleanUpManagedClusterManifests(t *testing.T) {
 				getApplyFileNames(c.applyFiles)...,
 			)
 			testinghelpers.AssertError(t, cleanUpErr, c.expectedErr)
-			c.validateActions(t, kubeClient.Actions())
+			c.validateActions(t, dynamicClient.Actions())
 		})
 	}
 }
@@ -327,6 +479,8 @@ func TestCleanUpManagedClusterManifests(t *testing.T) {
 func TestCleanUpGroupFromClusterRoleBindings(t *testing.T) {
 	cases := []struct {
 		name            string
+		preserve        bool
+		legacySweep     bool
 		object          []runtime.Object
 		validateActions func(t *testing.T, actions []clienttesting.Action)
 	}{
@@ -334,13 +488,13 @@ func TestCleanUpGroupFromClusterRoleBindings(t *testing.T) {
 			name: "clean up group from clusterrolebindings",
 			object: []runtime.Object{
 				&rbacv1.ClusterRoleBinding{
-					ObjectMeta: metav1.ObjectMeta{Name: "crb1"},
+					ObjectMeta: metav1.ObjectMeta{Name: "crb1", Labels: map[string]string{ClusterLabel: "testcluster"}},
 					Subjects: []rbacv1.Subject{
 						{Kind: "Group", Name: testManagedClusterGroup},
 					},
 				},
 				&rbacv1.ClusterRoleBinding{
-					ObjectMeta: metav1.ObjectMeta{Name: "crb2"},
+					ObjectMeta: metav1.ObjectMeta{Name: "crb2", Labels: map[string]string{ClusterLabel: "testcluster"}},
 					Subjects: []rbacv1.Subject{
 						{Kind: "Group", Name: testManagedClusterGroup},
 						{Kind: "Group", Name: "test"},
@@ -348,7 +502,7 @@ func TestCleanUpGroupFromClusterRoleBindings(t *testing.T) {
 					},
 				},
 				&rbacv1.ClusterRoleBinding{
-					ObjectMeta: metav1.ObjectMeta{Name: "crb3"},
+					ObjectMeta: metav1.ObjectMeta{Name: "crb3", Labels: map[string]string{ClusterLabel: "testcluster"}},
 					Subjects: []rbacv1.Subject{
 						{Kind: "Group", Name: "test"},
 					},
@@ -368,6 +522,48 @@ func TestCleanUpGroupFromClusterRoleBindings(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:        "legacy sweep finds unlabeled clusterrolebindings",
+			legacySweep: true,
+			object: []runtime.Object{
+				&rbacv1.ClusterRoleBinding{
+					ObjectMeta: metav1.ObjectMeta{Name: "crb1"},
+					Subjects: []rbacv1.Subject{
+						{Kind: "Group", Name: testManagedClusterGroup},
+					},
+				},
+			},
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "list", "delete")
+			},
+		},
+		{
+			name: "unlabeled clusterrolebindings are skipped without legacy sweep",
+			object: []runtime.Object{
+				&rbacv1.ClusterRoleBinding{
+					ObjectMeta: metav1.ObjectMeta{Name: "crb1"},
+					Subjects: []rbacv1.Subject{
+						{Kind: "Group", Name: testManagedClusterGroup},
+					},
+				},
+			},
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "list")
+			},
+		},
+		{
+			name:     "preserve resources on deletion leaves bindings untouched",
+			preserve: true,
+			object: []runtime.Object{
+				&rbacv1.ClusterRoleBinding{
+					ObjectMeta: metav1.ObjectMeta{Name: "crb1"},
+					Subjects: []rbacv1.Subject{
+						{Kind: "Group", Name: testManagedClusterGroup},
+					},
+				},
+			},
+			validateActions: testinghelpers.AssertNoActions,
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
@@ -376,6 +572,9 @@ func TestCleanUpGroupFromClusterRoleBindings(t *testing.T) {
 				context.TODO(),
 				kubeClient,
 				eventstesting.NewTestingEventRecorder(t),
+				c.preserve,
+				c.legacySweep,
+				"testcluster",
 				testManagedClusterGroup,
 			)
 			if err != nil {
@@ -390,6 +589,8 @@ func TestCleanUpGroupFromClusterRoleBindings(t *testing.T) {
 func TestCleanUpGroupFromRoleBindings(t *testing.T) {
 	cases := []struct {
 		name            string
+		preserve        bool
+		legacySweep     bool
 		object          []runtime.Object
 		validateActions func(t *testing.T, actions []clienttesting.Action)
 	}{
@@ -397,13 +598,13 @@ func TestCleanUpGroupFromRoleBindings(t *testing.T) {
 			name: "clean up group from rolebindings",
 			object: []runtime.Object{
 				&rbacv1.RoleBinding{
-					ObjectMeta: metav1.ObjectMeta{Name: "rb1", Namespace: "n1"},
+					ObjectMeta: metav1.ObjectMeta{Name: "rb1", Namespace: "n1", Labels: map[string]string{ClusterLabel: "testcluster"}},
 					Subjects: []rbacv1.Subject{
 						{Kind: "Group", Name: testManagedClusterGroup},
 					},
 				},
 				&rbacv1.RoleBinding{
-					ObjectMeta: metav1.ObjectMeta{Name: "rb2", Namespace: "n1"},
+					ObjectMeta: metav1.ObjectMeta{Name: "rb2", Namespace: "n1", Labels: map[string]string{ClusterLabel: "testcluster"}},
 					Subjects: []rbacv1.Subject{
 						{Kind: "Group", Name: testManagedClusterGroup},
 						{Kind: "Group", Name: "test"},
@@ -411,7 +612,7 @@ func TestCleanUpGroupFromRoleBindings(t *testing.T) {
 					},
 				},
 				&rbacv1.RoleBinding{
-					ObjectMeta: metav1.ObjectMeta{Name: "rb3", Namespace: "n2"},
+					ObjectMeta: metav1.ObjectMeta{Name: "rb3", Namespace: "n2", Labels: map[string]string{ClusterLabel: "testcluster"}},
 					Subjects: []rbacv1.Subject{
 						{Kind: "Group", Name: "test"},
 					},
@@ -432,6 +633,34 @@ func TestCleanUpGroupFromRoleBindings(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:        "legacy sweep finds unlabeled rolebindings",
+			legacySweep: true,
+			object: []runtime.Object{
+				&rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{Name: "rb1", Namespace: "n1"},
+					Subjects: []rbacv1.Subject{
+						{Kind: "Group", Name: testManagedClusterGroup},
+					},
+				},
+			},
+			validateActions: func(t *testing.T, actions []clienttesting.Action) {
+				testinghelpers.AssertActions(t, actions, "list", "delete")
+			},
+		},
+		{
+			name:     "preserve resources on deletion leaves bindings untouched",
+			preserve: true,
+			object: []runtime.Object{
+				&rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{Name: "rb1", Namespace: "n1"},
+					Subjects: []rbacv1.Subject{
+						{Kind: "Group", Name: testManagedClusterGroup},
+					},
+				},
+			},
+			validateActions: testinghelpers.AssertNoActions,
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
@@ -440,6 +669,9 @@ func TestCleanUpGroupFromRoleBindings(t *testing.T) {
 				context.TODO(),
 				kubeClient,
 				eventstesting.NewTestingEventRecorder(t),
+				c.preserve,
+				c.legacySweep,
+				"testcluster",
 				testManagedClusterGroup,
 			)
 			if err != nil {
@@ -0,0 +1,214 @@
+// Package health tracks, per ManagedCluster, how long a set of watched status conditions have been stuck
+// in an undesired state (the "unhealthy duration map" pattern), and escalates clusters that stay unhealthy
+// past a threshold by marking them ClusterDegraded and optionally fencing their RBAC group.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"open-cluster-management.io/registration/pkg/helpers"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ClusterDegraded is set True on a ManagedCluster once it has been unhealthy for longer than the
+// controller's configured threshold.
+const ClusterDegraded = "ClusterDegraded"
+
+// DefaultUnhealthyThreshold is the default threshold for NewController. NewController does not apply it
+// itself, and no flag in this module sets the threshold: callers pass it explicitly.
+const DefaultUnhealthyThreshold = 5 * time.Minute
+
+// ConditionWatch names a status condition whose presence at Status indicates the cluster is unhealthy.
+type ConditionWatch struct {
+	Type   string
+	Status metav1.ConditionStatus
+}
+
+// DefaultWatchedConditions returns the conditions this controller watches when the hub controller does not
+// override them.
+func DefaultWatchedConditions() []ConditionWatch {
+	return []ConditionWatch{
+		{Type: "ManagedClusterConditionAvailable", Status: metav1.ConditionFalse},
+		{Type: "ManagedClusterJoined", Status: metav1.ConditionFalse},
+	}
+}
+
+// DefaultManagedClusterGroup returns the RBAC group fenced for clusterName when fencing is enabled,
+// matching the group the hub grants a spoke's registration agent on join.
+func DefaultManagedClusterGroup(clusterName string) string {
+	return fmt.Sprintf("system:open-cluster-management:%s", clusterName)
+}
+
+// Controller tracks per-cluster unhealthy duration and escalates clusters that cross threshold.
+type Controller struct {
+	clusterClient  clusterclientset.Interface
+	kubeClient     kubernetes.Interface
+	recorder       events.Recorder
+	threshold      time.Duration
+	watched        []ConditionWatch
+	fenceRBAC      bool
+	legacySweep    bool
+	groupForOption func(clusterName string) string
+	now            func() time.Time
+
+	mu             sync.Mutex
+	unhealthySince map[string]map[string]time.Time
+}
+
+// NewController returns a Controller that marks a ManagedCluster ClusterDegraded once any of watched has
+// been continuously in its undesired Status for threshold. When fenceRBAC is true, crossing threshold also
+// scrubs the cluster's group from cluster-scoped and namespaced RoleBindings via
+// CleanUpGroupFromClusterRoleBindings/CleanUpGroupFromRoleBindings, using groupForCluster to compute the
+// group name. legacySweep is forwarded to those cleanup calls: set it until every RoleBinding/
+// ClusterRoleBinding the hub applies is guaranteed to carry helpers.ClusterLabel, since the default
+// label-selector cleanup otherwise matches nothing for bindings that predate the stamp.
+func NewController(
+	clusterClient clusterclientset.Interface,
+	kubeClient kubernetes.Interface,
+	recorder events.Recorder,
+	threshold time.Duration,
+	watched []ConditionWatch,
+	fenceRBAC bool,
+	groupForCluster func(clusterName string) string,
+	legacySweep bool) *Controller {
+	if groupForCluster == nil {
+		groupForCluster = DefaultManagedClusterGroup
+	}
+	return &Controller{
+		clusterClient:  clusterClient,
+		kubeClient:     kubeClient,
+		recorder:       recorder,
+		threshold:      threshold,
+		watched:        watched,
+		fenceRBAC:      fenceRBAC,
+		legacySweep:    legacySweep,
+		groupForOption: groupForCluster,
+		now:            time.Now,
+		unhealthySince: map[string]map[string]time.Time{},
+	}
+}
+
+// Sync recomputes cluster's unhealthy duration against every watched condition and, depending on whether
+// the threshold has been crossed, marks it degraded (and optionally fences its RBAC group) or clears a
+// previously-set ClusterDegraded condition.
+func (c *Controller) Sync(ctx context.Context, cluster *clusterv1.ManagedCluster) error {
+	name := cluster.Name
+	now := c.now()
+
+	crossed := false
+	healthy := true
+	for _, watch := range c.watched {
+		cond := meta.FindStatusCondition(cluster.Status.Conditions, watch.Type)
+		if cond == nil || cond.Status != watch.Status {
+			c.clearSince(name, watch.Type)
+			continue
+		}
+		healthy = false
+		since := c.observeSince(name, watch.Type, now)
+		if now.Sub(since) >= c.threshold {
+			crossed = true
+		}
+	}
+
+	if crossed {
+		return c.markDegraded(ctx, name)
+	}
+	if healthy {
+		return c.clearDegraded(ctx, cluster, name)
+	}
+	// still unhealthy but under threshold: leave the recorded unhealthy-since timestamps alone so the
+	// threshold can actually be crossed by a later sync.
+	return nil
+}
+
+func (c *Controller) markDegraded(ctx context.Context, clusterName string) error {
+	cond := metav1.Condition{
+		Type:    ClusterDegraded,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ClusterUnhealthyPastThreshold",
+		Message: fmt.Sprintf("Cluster has remained unhealthy for at least %s", c.threshold),
+	}
+	if _, _, err := helpers.UpdateManagedClusterStatus(
+		ctx, c.clusterClient, clusterName, c.recorder, helpers.UpdateManagedClusterConditionFn(cond)); err != nil {
+		return err
+	}
+
+	if !c.fenceRBAC {
+		return nil
+	}
+	group := c.groupForOption(clusterName)
+	if err := helpers.CleanUpGroupFromClusterRoleBindings(
+		ctx, c.kubeClient, c.recorder, false, c.legacySweep, clusterName, group); err != nil {
+		return err
+	}
+	return helpers.CleanUpGroupFromRoleBindings(
+		ctx, c.kubeClient, c.recorder, false, c.legacySweep, clusterName, group)
+}
+
+func (c *Controller) clearDegraded(ctx context.Context, cluster *clusterv1.ManagedCluster, clusterName string) error {
+	c.clearAllSince(clusterName)
+
+	existing := meta.FindStatusCondition(cluster.Status.Conditions, ClusterDegraded)
+	if existing == nil || existing.Status != metav1.ConditionTrue {
+		return nil
+	}
+
+	cond := metav1.Condition{
+		Type:    ClusterDegraded,
+		Status:  metav1.ConditionFalse,
+		Reason:  "ClusterHealthy",
+		Message: "Cluster has recovered from all watched unhealthy conditions",
+	}
+	_, _, err := helpers.UpdateManagedClusterStatus(
+		ctx, c.clusterClient, clusterName, c.recorder, helpers.UpdateManagedClusterConditionFn(cond))
+	return err
+}
+
+// observeSince returns the first time conditionType was observed unhealthy for clusterName, recording now
+// as that time if this is the first observation.
+func (c *Controller) observeSince(clusterName, conditionType string, now time.Time) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	perCluster, ok := c.unhealthySince[clusterName]
+	if !ok {
+		perCluster = map[string]time.Time{}
+		c.unhealthySince[clusterName] = perCluster
+	}
+	since, ok := perCluster[conditionType]
+	if !ok {
+		perCluster[conditionType] = now
+		return now
+	}
+	return since
+}
+
+// clearSince forgets the recorded unhealthy-since time for clusterName/conditionType, if any.
+func (c *Controller) clearSince(clusterName, conditionType string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	perCluster, ok := c.unhealthySince[clusterName]
+	if !ok {
+		return
+	}
+	delete(perCluster, conditionType)
+	if len(perCluster) == 0 {
+		delete(c.unhealthySince, clusterName)
+	}
+}
+
+// clearAllSince forgets every recorded unhealthy-since time for clusterName.
+func (c *Controller) clearAllSince(clusterName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.unhealthySince, clusterName)
+}
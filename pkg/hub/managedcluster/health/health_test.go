@@ -0,0 +1,161 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+
+	"github.com/openshift/library-go/pkg/operator/events/eventstesting"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekube "k8s.io/client-go/kubernetes/fake"
+)
+
+const testClusterName = "cluster1"
+
+func newTestCluster(conditions ...metav1.Condition) *clusterv1.ManagedCluster {
+	return &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: testClusterName},
+		Status:     clusterv1.ManagedClusterStatus{Conditions: conditions},
+	}
+}
+
+func availableFalse(t time.Time) metav1.Condition {
+	return metav1.Condition{
+		Type:               "ManagedClusterConditionAvailable",
+		Status:             metav1.ConditionFalse,
+		Reason:             "Unavailable",
+		LastTransitionTime: metav1.NewTime(t),
+	}
+}
+
+func newTestController(t *testing.T, clusterClient *clusterfake.Clientset, fenceRBAC bool) (*Controller, *fakekube.Clientset) {
+	kubeClient := fakekube.NewSimpleClientset()
+	c := NewController(
+		clusterClient,
+		kubeClient,
+		eventstesting.NewTestingEventRecorder(t),
+		5*time.Minute,
+		DefaultWatchedConditions(),
+		fenceRBAC,
+		nil,
+		false,
+	)
+	return c, kubeClient
+}
+
+func TestSyncFlapWithinThreshold(t *testing.T) {
+	cluster := newTestCluster(availableFalse(time.Now()))
+	clusterClient := clusterfake.NewSimpleClientset(cluster)
+	c, kubeClient := newTestController(t, clusterClient, true)
+
+	base := time.Date(2026, 7, 26, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return base }
+	if err := c.Sync(context.TODO(), cluster); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// still unhealthy, but only 4 minutes have passed: under the 5m threshold.
+	c.now = func() time.Time { return base.Add(4 * time.Minute) }
+	if err := c.Sync(context.TODO(), cluster); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	updated, err := clusterClient.ClusterV1().ManagedClusters().Get(context.TODO(), testClusterName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if meta.FindStatusCondition(updated.Status.Conditions, ClusterDegraded) != nil {
+		t.Errorf("expected no ClusterDegraded condition, got %v", updated.Status.Conditions)
+	}
+	if actions := kubeClient.Actions(); len(actions) != 0 {
+		t.Errorf("expected no RBAC actions, got %v", actions)
+	}
+}
+
+func TestSyncSustainedUnhealthy(t *testing.T) {
+	cluster := newTestCluster(availableFalse(time.Now()))
+	clusterClient := clusterfake.NewSimpleClientset(cluster)
+	c, kubeClient := newTestController(t, clusterClient, true)
+
+	base := time.Date(2026, 7, 26, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return base }
+	if err := c.Sync(context.TODO(), cluster); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// now 6 minutes unhealthy: past the 5m threshold.
+	c.now = func() time.Time { return base.Add(6 * time.Minute) }
+	if err := c.Sync(context.TODO(), cluster); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	updated, err := clusterClient.ClusterV1().ManagedClusters().Get(context.TODO(), testClusterName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	degraded := meta.FindStatusCondition(updated.Status.Conditions, ClusterDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue {
+		t.Errorf("expected ClusterDegraded=True, got %v", updated.Status.Conditions)
+	}
+
+	if actions := kubeClient.Actions(); len(actions) != 2 {
+		t.Errorf("expected RBAC to be scrubbed (one list each for cluster/role bindings), got %v", actions)
+	}
+}
+
+func TestSyncRecovery(t *testing.T) {
+	degradedSince := time.Date(2026, 7, 26, 0, 0, 0, 0, time.UTC)
+	cluster := newTestCluster(
+		metav1.Condition{
+			Type:               ClusterDegraded,
+			Status:             metav1.ConditionTrue,
+			Reason:             "ClusterUnhealthyPastThreshold",
+			LastTransitionTime: metav1.NewTime(degradedSince),
+		},
+	)
+	clusterClient := clusterfake.NewSimpleClientset(cluster)
+	c, kubeClient := newTestController(t, clusterClient, true)
+	c.now = func() time.Time { return degradedSince.Add(time.Minute) }
+
+	// pretend the controller previously recorded this cluster as unhealthy.
+	c.observeSince(testClusterName, "ManagedClusterConditionAvailable", degradedSince)
+
+	recovered := newTestCluster(
+		metav1.Condition{
+			Type:               ClusterDegraded,
+			Status:             metav1.ConditionTrue,
+			Reason:             "ClusterUnhealthyPastThreshold",
+			LastTransitionTime: metav1.NewTime(degradedSince),
+		},
+		metav1.Condition{
+			Type:   "ManagedClusterConditionAvailable",
+			Status: metav1.ConditionTrue,
+			Reason: "Available",
+		},
+	)
+
+	if err := c.Sync(context.TODO(), recovered); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if _, ok := c.unhealthySince[testClusterName]; ok {
+		t.Errorf("expected unhealthy-since entries for %s to be cleared", testClusterName)
+	}
+
+	updated, err := clusterClient.ClusterV1().ManagedClusters().Get(context.TODO(), testClusterName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	degraded := meta.FindStatusCondition(updated.Status.Conditions, ClusterDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionFalse {
+		t.Errorf("expected ClusterDegraded=False after recovery, got %v", updated.Status.Conditions)
+	}
+	if actions := kubeClient.Actions(); len(actions) != 0 {
+		t.Errorf("expected no RBAC actions on recovery, got %v", actions)
+	}
+}
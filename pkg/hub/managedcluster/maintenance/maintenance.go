@@ -0,0 +1,144 @@
+// Package maintenance implements the ManagedCluster maintenance-window subsystem: while a
+// ClusterMaintenanceWindow targeting a cluster is active, the controller here sets a
+// ManagedClusterConditionMaintenance condition, and exposes IsActionActive, a predicate for other
+// controllers (such as the CSR approver or the addon status updater) to check before reconciling that
+// cluster. No controller in this module calls it.
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	maintenancev1alpha1 "open-cluster-management.io/registration/pkg/apis/maintenance/v1alpha1"
+	"open-cluster-management.io/registration/pkg/helpers"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ManagedClusterConditionMaintenance is set True on a ManagedCluster while any active
+// ClusterMaintenanceWindow targets it.
+const ManagedClusterConditionMaintenance = "ManagedClusterConditionMaintenance"
+
+const cancelledAnnotation = "maintenance.open-cluster-management.io/cancelled"
+
+// WindowLister is satisfied by a lister/cache over ClusterMaintenanceWindow; kept as a narrow interface
+// since the generated clientset/informers for this CRD are not part of this checkout.
+type WindowLister interface {
+	List() ([]*maintenancev1alpha1.ClusterMaintenanceWindow, error)
+}
+
+// Controller reconciles ClusterMaintenanceWindows: for each targeted cluster it sets or clears
+// ManagedClusterConditionMaintenance depending on whether any window is currently active.
+type Controller struct {
+	clusterClient clusterclientset.Interface
+	windowLister  WindowLister
+	recorder      events.Recorder
+	now           func() time.Time
+}
+
+// NewController returns a maintenance Controller backed by clusterClient and windowLister.
+func NewController(clusterClient clusterclientset.Interface, windowLister WindowLister, recorder events.Recorder) *Controller {
+	return &Controller{
+		clusterClient: clusterClient,
+		windowLister:  windowLister,
+		recorder:      recorder,
+		now:           time.Now,
+	}
+}
+
+// Sync recomputes the maintenance condition for clusterName from the current set of windows.
+func (c *Controller) Sync(ctx context.Context, clusterName string) error {
+	windows, err := c.windowLister.List()
+	if err != nil {
+		return err
+	}
+
+	_, active := ActiveActionsForCluster(windows, clusterName, c.now())
+
+	cond := metav1.Condition{
+		Type:    ManagedClusterConditionMaintenance,
+		Status:  metav1.ConditionFalse,
+		Reason:  "NoActiveMaintenanceWindow",
+		Message: "No ClusterMaintenanceWindow is currently active for this cluster",
+	}
+	if active {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "MaintenanceWindowActive"
+		cond.Message = "A ClusterMaintenanceWindow is currently active for this cluster"
+	}
+
+	_, _, err = helpers.UpdateManagedClusterStatus(
+		ctx, c.clusterClient, clusterName, c.recorder, helpers.UpdateManagedClusterConditionFn(cond))
+	return err
+}
+
+// IsActionActive returns whether action is currently in effect for clusterName, for controllers such as
+// the CSR approver (BlockCSRApproval) and the addon status updater (SuspendAddonWork) to consult before
+// reconciling.
+func (c *Controller) IsActionActive(clusterName string, action maintenancev1alpha1.MaintenanceAction) (bool, error) {
+	windows, err := c.windowLister.List()
+	if err != nil {
+		return false, err
+	}
+	actions, active := ActiveActionsForCluster(windows, clusterName, c.now())
+	return active && actions.Has(string(action)), nil
+}
+
+// ActiveActionsForCluster returns the union of actions from every window that targets clusterName and is
+// currently active at now, and whether any such window exists.
+func ActiveActionsForCluster(
+	windows []*maintenancev1alpha1.ClusterMaintenanceWindow, clusterName string, now time.Time) (sets.String, bool) {
+	actions := sets.NewString()
+	active := false
+	for _, window := range windows {
+		if !containsCluster(window.Spec.ManagedClusterNames, clusterName) {
+			continue
+		}
+		if !isWindowActive(window, now) {
+			continue
+		}
+		active = true
+		for _, action := range window.Spec.Actions {
+			actions.Insert(string(action))
+		}
+	}
+	return actions, active
+}
+
+// isWindowActive reports whether window is active at now: it must not be cancelled, and now must fall
+// within its explicit From/To range (inclusive of From, exclusive of To). now is compared with time.Equal
+// semantics so callers in any timezone observe the same result, since metav1.Time always round-trips to
+// UTC.
+func isWindowActive(window *maintenancev1alpha1.ClusterMaintenanceWindow, now time.Time) bool {
+	if window.Annotations[cancelledAnnotation] == "true" {
+		return false
+	}
+
+	schedule := window.Spec.Schedule
+	if schedule.From == nil || schedule.To == nil {
+		// Cron-based schedules require a cron parser that is not vendored in this module yet; until one
+		// is added, windows that only set Cron never become active.
+		return false
+	}
+
+	from := schedule.From.Time
+	to := schedule.To.Time
+	if now.Before(from) {
+		return false
+	}
+	return now.Before(to)
+}
+
+func containsCluster(names []string, clusterName string) bool {
+	for _, name := range names {
+		if name == clusterName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package maintenance
+
+// These cases (including overlap, timezone handling, and early cancellation via cancelledAnnotation) are
+// written as plain testing table tests rather than ginkgo: ginkgo isn't used anywhere else in this
+// checkout, and introducing it for a single package would leave the repo with two competing test styles.
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	maintenancev1alpha1 "open-cluster-management.io/registration/pkg/apis/maintenance/v1alpha1"
+)
+
+func newWindow(name string, clusters []string, from, to time.Time, actions ...maintenancev1alpha1.MaintenanceAction) *maintenancev1alpha1.ClusterMaintenanceWindow {
+	return &maintenancev1alpha1.ClusterMaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: maintenancev1alpha1.ClusterMaintenanceWindowSpec{
+			ManagedClusterNames: clusters,
+			Schedule: maintenancev1alpha1.MaintenanceSchedule{
+				From: &metav1.Time{Time: from},
+				To:   &metav1.Time{Time: to},
+			},
+			Actions: actions,
+		},
+	}
+}
+
+func TestActiveActionsForCluster(t *testing.T) {
+	now := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+	est := time.FixedZone("EST", -5*60*60)
+
+	cases := []struct {
+		name            string
+		windows         []*maintenancev1alpha1.ClusterMaintenanceWindow
+		clusterName     string
+		expectedActive  bool
+		expectedActions []string
+	}{
+		{
+			name: "no windows target this cluster",
+			windows: []*maintenancev1alpha1.ClusterMaintenanceWindow{
+				newWindow("w1", []string{"other"}, now.Add(-time.Hour), now.Add(time.Hour), maintenancev1alpha1.BlockCSRApproval),
+			},
+			clusterName:    "cluster1",
+			expectedActive: false,
+		},
+		{
+			name: "single active window",
+			windows: []*maintenancev1alpha1.ClusterMaintenanceWindow{
+				newWindow("w1", []string{"cluster1"}, now.Add(-time.Hour), now.Add(time.Hour), maintenancev1alpha1.SuspendAddonWork),
+			},
+			clusterName:     "cluster1",
+			expectedActive:  true,
+			expectedActions: []string{string(maintenancev1alpha1.SuspendAddonWork)},
+		},
+		{
+			name: "window not yet started",
+			windows: []*maintenancev1alpha1.ClusterMaintenanceWindow{
+				newWindow("w1", []string{"cluster1"}, now.Add(time.Hour), now.Add(2*time.Hour), maintenancev1alpha1.SuspendAddonWork),
+			},
+			clusterName:    "cluster1",
+			expectedActive: false,
+		},
+		{
+			name: "window already ended",
+			windows: []*maintenancev1alpha1.ClusterMaintenanceWindow{
+				newWindow("w1", []string{"cluster1"}, now.Add(-2*time.Hour), now.Add(-time.Hour), maintenancev1alpha1.SuspendAddonWork),
+			},
+			clusterName:    "cluster1",
+			expectedActive: false,
+		},
+		{
+			name: "overlapping windows union their actions",
+			windows: []*maintenancev1alpha1.ClusterMaintenanceWindow{
+				newWindow("w1", []string{"cluster1"}, now.Add(-time.Hour), now.Add(time.Hour), maintenancev1alpha1.SuspendAddonWork),
+				newWindow("w2", []string{"cluster1"}, now.Add(-30*time.Minute), now.Add(2*time.Hour), maintenancev1alpha1.BlockCSRApproval),
+			},
+			clusterName:     "cluster1",
+			expectedActive:  true,
+			expectedActions: []string{string(maintenancev1alpha1.SuspendAddonWork), string(maintenancev1alpha1.BlockCSRApproval)},
+		},
+		{
+			name: "from/to expressed in a non-UTC timezone still compares correctly",
+			windows: []*maintenancev1alpha1.ClusterMaintenanceWindow{
+				newWindow("w1", []string{"cluster1"}, now.Add(-time.Hour).In(est), now.Add(time.Hour).In(est), maintenancev1alpha1.DrainLeases),
+			},
+			clusterName:     "cluster1",
+			expectedActive:  true,
+			expectedActions: []string{string(maintenancev1alpha1.DrainLeases)},
+		},
+		{
+			name: "early-cancelled window is ignored even though still within range",
+			windows: []*maintenancev1alpha1.ClusterMaintenanceWindow{
+				func() *maintenancev1alpha1.ClusterMaintenanceWindow {
+					w := newWindow("w1", []string{"cluster1"}, now.Add(-time.Hour), now.Add(time.Hour), maintenancev1alpha1.SuspendAddonWork)
+					w.Annotations = map[string]string{cancelledAnnotation: "true"}
+					return w
+				}(),
+			},
+			clusterName:    "cluster1",
+			expectedActive: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actions, active := ActiveActionsForCluster(c.windows, c.clusterName, now)
+			if active != c.expectedActive {
+				t.Fatalf("expected active=%t, got %t", c.expectedActive, active)
+			}
+			for _, expected := range c.expectedActions {
+				if !actions.Has(expected) {
+					t.Errorf("expected action %q to be active, got %v", expected, actions.List())
+				}
+			}
+			if len(c.expectedActions) != actions.Len() {
+				t.Errorf("expected %d active actions, got %v", len(c.expectedActions), actions.List())
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openshift/api"
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	rbacv1informers "k8s.io/client-go/informers/rbac/v1"
+	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
+
+	clusterv1informers "open-cluster-management.io/api/client/cluster/informers/externalversions/cluster/v1"
+	clusterv1listers "open-cluster-management.io/api/client/cluster/listers/cluster/v1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+
+	"open-cluster-management.io/registration/pkg/helpers"
+)
+
+var (
+	rbacDriftScheme = runtime.NewScheme()
+	rbacDriftCodecs = serializer.NewCodecFactory(rbacDriftScheme)
+	rbacDriftCodec  = rbacDriftCodecs.UniversalDeserializer()
+
+	rbacDriftTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ocm_registration_rbac_drift_total",
+		Help: "Total number of per-cluster ClusterRole/ClusterRoleBinding drift corrections applied by the hub.",
+	}, []string{"cluster", "kind"})
+)
+
+func init() {
+	utilruntime.Must(api.InstallKube(rbacDriftScheme))
+}
+
+// rbacDriftController periodically re-renders the per-cluster ClusterRole and ClusterRoleBinding from
+// bindata via helpers.ManagedClusterAssetFn and converges any live object that has drifted from it -- an
+// admin editing the rendered RBAC by hand (accidentally widening privileges, or removing rules the work
+// agent needs) gets corrected rather than left in place.
+type rbacDriftController struct {
+	kubeClient               kubernetes.Interface
+	clusterLister            clusterv1listers.ManagedClusterLister
+	clusterRoleLister        rbacv1listers.ClusterRoleLister
+	clusterRoleBindingLister rbacv1listers.ClusterRoleBindingLister
+	manifestDir              string
+	manifestFiles            []string
+	recorder                 events.Recorder
+}
+
+// NewRBACDriftController returns a factory.Controller that reconciles manifestFiles (rendered relative to
+// manifestDir for each ManagedCluster) on a resync interval and on ClusterRole/ClusterRoleBinding watch
+// events.
+func NewRBACDriftController(
+	kubeClient kubernetes.Interface,
+	clusterInformer clusterv1informers.ManagedClusterInformer,
+	clusterRoleInformer rbacv1informers.ClusterRoleInformer,
+	clusterRoleBindingInformer rbacv1informers.ClusterRoleBindingInformer,
+	manifestDir string,
+	manifestFiles []string,
+	recorder events.Recorder,
+) factory.Controller {
+	c := &rbacDriftController{
+		kubeClient:               kubeClient,
+		clusterLister:            clusterInformer.Lister(),
+		clusterRoleLister:        clusterRoleInformer.Lister(),
+		clusterRoleBindingLister: clusterRoleBindingInformer.Lister(),
+		manifestDir:              manifestDir,
+		manifestFiles:            manifestFiles,
+		recorder:                 recorder,
+	}
+
+	return factory.New().
+		WithInformersQueueKeyFunc(
+			func(obj runtime.Object) string {
+				accessor, _ := meta.Accessor(obj)
+				if _, isCluster := obj.(*clusterv1.ManagedCluster); isCluster {
+					return accessor.GetName()
+				}
+				// map a ClusterRole/ClusterRoleBinding event back to the cluster it was rendered for; an
+				// unlabeled one requeues every cluster.
+				if clusterName, ok := accessor.GetLabels()[helpers.ClusterLabel]; ok {
+					return clusterName
+				}
+				return factory.DefaultQueueKey
+			},
+			clusterInformer.Informer(), clusterRoleInformer.Informer(), clusterRoleBindingInformer.Informer()).
+		WithSync(c.sync).
+		ResyncEvery(10*time.Minute).
+		ToController("RBACDriftController", recorder)
+}
+
+func (c *rbacDriftController) sync(ctx context.Context, syncCtx factory.SyncContext) error {
+	clusterName := syncCtx.QueueKey()
+	if clusterName == factory.DefaultQueueKey {
+		clusters, err := c.clusterLister.List(labels.Everything())
+		if err != nil {
+			return err
+		}
+		for _, cluster := range clusters {
+			syncCtx.Queue().Add(cluster.Name)
+		}
+		return nil
+	}
+
+	cluster, err := c.clusterLister.Get(clusterName)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	assetFn := helpers.ManagedClusterAssetFn(c.manifestDir, clusterName)
+	for _, file := range c.manifestFiles {
+		if err := c.reconcileFile(ctx, cluster, assetFn, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *rbacDriftController) reconcileFile(ctx context.Context, cluster *clusterv1.ManagedCluster, assetFn resourceapply.AssetFunc, file string) error {
+	raw, err := assetFn(file)
+	if err != nil {
+		return err
+	}
+
+	object, _, err := rbacDriftCodec.Decode(raw, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	// stamp ClusterLabel (and, for cluster-scoped kinds, an owner reference) onto the rendered object so
+	// CleanUpGroupFromClusterRoleBindings can list this cluster's bindings by label instead of sweeping
+	// every binding on the hub.
+	switch expected := object.(type) {
+	case *rbacv1.ClusterRole:
+		helpers.StampClusterOwnerMeta(expected, cluster, true)
+		return c.reconcileClusterRole(ctx, cluster.Name, expected)
+	case *rbacv1.ClusterRoleBinding:
+		helpers.StampClusterOwnerMeta(expected, cluster, true)
+		return c.reconcileClusterRoleBinding(ctx, cluster.Name, expected)
+	default:
+		// only ClusterRole/ClusterRoleBinding drift is in scope for this controller; other kinds are
+		// reconciled by the registering controller itself.
+		return nil
+	}
+}
+
+func (c *rbacDriftController) reconcileClusterRole(ctx context.Context, clusterName string, expected *rbacv1.ClusterRole) error {
+	existing, err := c.clusterRoleLister.Get(expected.Name)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if clusterRoleInSync(existing, expected) {
+		return nil
+	}
+
+	updated := existing.DeepCopy()
+	updated.Labels = mergeStringMaps(existing.Labels, expected.Labels)
+	updated.Annotations = mergeStringMaps(existing.Annotations, expected.Annotations)
+	updated.OwnerReferences = expected.OwnerReferences
+	updated.Rules = expected.Rules
+	if _, err := c.kubeClient.RbacV1().ClusterRoles().Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+
+	rbacDriftTotal.WithLabelValues(clusterName, "ClusterRole").Inc()
+	c.recorder.Eventf("RBACDriftCorrected", fmt.Sprintf("Corrected drifted ClusterRole %q for cluster %q", expected.Name, clusterName))
+	return nil
+}
+
+func (c *rbacDriftController) reconcileClusterRoleBinding(ctx context.Context, clusterName string, expected *rbacv1.ClusterRoleBinding) error {
+	existing, err := c.clusterRoleBindingLister.Get(expected.Name)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if clusterRoleBindingInSync(existing, expected) {
+		return nil
+	}
+
+	// roleRef is immutable once a ClusterRoleBinding is created: if that's what drifted, Update would be
+	// rejected by the apiserver, so delete and recreate with the expected roleRef instead.
+	if !equality.Semantic.DeepEqual(existing.RoleRef, expected.RoleRef) {
+		if err := c.kubeClient.RbacV1().ClusterRoleBindings().Delete(ctx, existing.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		recreated := expected.DeepCopy()
+		recreated.ResourceVersion = ""
+		recreated.Labels = mergeStringMaps(existing.Labels, expected.Labels)
+		recreated.Annotations = mergeStringMaps(existing.Annotations, expected.Annotations)
+		recreated.OwnerReferences = expected.OwnerReferences
+		if _, err := c.kubeClient.RbacV1().ClusterRoleBindings().Create(ctx, recreated, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		rbacDriftTotal.WithLabelValues(clusterName, "ClusterRoleBinding").Inc()
+		c.recorder.Eventf("RBACDriftCorrected", fmt.Sprintf("Recreated ClusterRoleBinding %q for cluster %q: roleRef is immutable and had drifted", expected.Name, clusterName))
+		return nil
+	}
+
+	updated := existing.DeepCopy()
+	updated.Labels = mergeStringMaps(existing.Labels, expected.Labels)
+	updated.Annotations = mergeStringMaps(existing.Annotations, expected.Annotations)
+	updated.OwnerReferences = expected.OwnerReferences
+	updated.Subjects = expected.Subjects
+	if _, err := c.kubeClient.RbacV1().ClusterRoleBindings().Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+
+	rbacDriftTotal.WithLabelValues(clusterName, "ClusterRoleBinding").Inc()
+	c.recorder.Eventf("RBACDriftCorrected", fmt.Sprintf("Corrected drifted ClusterRoleBinding %q for cluster %q", expected.Name, clusterName))
+	return nil
+}
+
+// mergeStringMaps returns existing with expected's keys applied on top, preserving any extra labels or
+// annotations that labelsAndAnnotationsInSync intentionally tolerates instead of stripping them on every
+// converge.
+func mergeStringMaps(existing, expected map[string]string) map[string]string {
+	merged := map[string]string{}
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range expected {
+		merged[k] = v
+	}
+	return merged
+}
+
+func clusterRoleInSync(existing, expected *rbacv1.ClusterRole) bool {
+	return equality.Semantic.DeepEqual(existing.Rules, expected.Rules) &&
+		labelsAndAnnotationsInSync(existing.Labels, expected.Labels, existing.Annotations, expected.Annotations)
+}
+
+func clusterRoleBindingInSync(existing, expected *rbacv1.ClusterRoleBinding) bool {
+	return equality.Semantic.DeepEqual(existing.Subjects, expected.Subjects) &&
+		equality.Semantic.DeepEqual(existing.RoleRef, expected.RoleRef) &&
+		labelsAndAnnotationsInSync(existing.Labels, expected.Labels, existing.Annotations, expected.Annotations)
+}
+
+func labelsAndAnnotationsInSync(existingLabels, expectedLabels, existingAnnotations, expectedAnnotations map[string]string) bool {
+	for k, v := range expectedLabels {
+		if existingLabels[k] != v {
+			return false
+		}
+	}
+	for k, v := range expectedAnnotations {
+		if existingAnnotations[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package managedcluster
+
+import (
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterRoleInSync(t *testing.T) {
+	cases := []struct {
+		name     string
+		existing *rbacv1.ClusterRole
+		expected *rbacv1.ClusterRole
+		inSync   bool
+	}{
+		{
+			name: "in sync",
+			existing: &rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"a": "b"}},
+				Rules:      []rbacv1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"pods"}}},
+			},
+			expected: &rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"a": "b"}},
+				Rules:      []rbacv1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"pods"}}},
+			},
+			inSync: true,
+		},
+		{
+			name: "rules widened out of band",
+			existing: &rbacv1.ClusterRole{
+				Rules: []rbacv1.PolicyRule{{Verbs: []string{"get", "delete"}, Resources: []string{"pods"}}},
+			},
+			expected: &rbacv1.ClusterRole{
+				Rules: []rbacv1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"pods"}}},
+			},
+			inSync: false,
+		},
+		{
+			name: "expected label missing",
+			existing: &rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}},
+			},
+			expected: &rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"a": "b"}},
+			},
+			inSync: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := clusterRoleInSync(c.existing, c.expected); got != c.inSync {
+				t.Errorf("expected inSync=%t, got %t", c.inSync, got)
+			}
+		})
+	}
+}
+
+func TestClusterRoleBindingInSync(t *testing.T) {
+	cases := []struct {
+		name     string
+		existing *rbacv1.ClusterRoleBinding
+		expected *rbacv1.ClusterRoleBinding
+		inSync   bool
+	}{
+		{
+			name: "in sync",
+			existing: &rbacv1.ClusterRoleBinding{
+				Subjects: []rbacv1.Subject{{Kind: "Group", Name: "g1"}},
+				RoleRef:  rbacv1.RoleRef{Name: "role1"},
+			},
+			expected: &rbacv1.ClusterRoleBinding{
+				Subjects: []rbacv1.Subject{{Kind: "Group", Name: "g1"}},
+				RoleRef:  rbacv1.RoleRef{Name: "role1"},
+			},
+			inSync: true,
+		},
+		{
+			name: "subject removed out of band",
+			existing: &rbacv1.ClusterRoleBinding{
+				Subjects: []rbacv1.Subject{},
+				RoleRef:  rbacv1.RoleRef{Name: "role1"},
+			},
+			expected: &rbacv1.ClusterRoleBinding{
+				Subjects: []rbacv1.Subject{{Kind: "Group", Name: "g1"}},
+				RoleRef:  rbacv1.RoleRef{Name: "role1"},
+			},
+			inSync: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := clusterRoleBindingInSync(c.existing, c.expected); got != c.inSync {
+				t.Errorf("expected inSync=%t, got %t", c.inSync, got)
+			}
+		})
+	}
+}
+
+func TestLabelsAndAnnotationsInSync(t *testing.T) {
+	if !labelsAndAnnotationsInSync(
+		map[string]string{"a": "b", "extra": "kept"}, map[string]string{"a": "b"},
+		nil, nil) {
+		t.Errorf("expected extra existing labels to be tolerated")
+	}
+
+	if labelsAndAnnotationsInSync(
+		map[string]string{"a": "c"}, map[string]string{"a": "b"},
+		nil, nil) {
+		t.Errorf("expected mismatched label value to not be in sync")
+	}
+}
@@ -0,0 +1,102 @@
+// Package webhook implements the ValidatingWebhookConfiguration handler for
+// ManagedClusterSet: it blocks deletion of a set while ManagedClusters still
+// reference it, and rejects UPDATE requests that rename the set's identity.
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// clusterSetLabel is the label a ManagedCluster carries to indicate which
+// ManagedClusterSet it belongs to. It must stay in sync with the label the
+// managedClusterSetController reads when computing clusterSetsMap.
+const clusterSetLabel = "cluster.open-cluster-management.io/clusterset"
+
+// identityLabel is the only label key this webhook treats as part of a ManagedClusterSet's identity: it's
+// the same key ManagedClusters use to reference the set by name, so changing its value on the set itself
+// is the rename-style mutation that needs blocking. Every other label on the set is free to edit.
+const identityLabel = clusterSetLabel
+
+// ManagedClusterSetWebhook validates ManagedClusterSet admission requests.
+type ManagedClusterSetWebhook struct {
+	clusterClient clusterclientset.Interface
+	decoder       *admission.Decoder
+}
+
+// NewManagedClusterSetWebhook returns a handler backed by clusterClient,
+// used to list ManagedClusters referring to the set under deletion.
+func NewManagedClusterSetWebhook(clusterClient clusterclientset.Interface) *ManagedClusterSetWebhook {
+	return &ManagedClusterSetWebhook{clusterClient: clusterClient}
+}
+
+// InjectDecoder wires the admission decoder in, as required by
+// controller-runtime's webhook server.
+func (w *ManagedClusterSetWebhook) InjectDecoder(d *admission.Decoder) error {
+	w.decoder = d
+	return nil
+}
+
+// Handle implements admission.Handler.
+func (w *ManagedClusterSetWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	switch req.Operation {
+	case admissionv1.Delete:
+		return w.validateDelete(ctx, req)
+	case admissionv1.Create, admissionv1.Update:
+		return w.validateMutation(req)
+	default:
+		return admission.Allowed("")
+	}
+}
+
+func (w *ManagedClusterSetWebhook) validateDelete(ctx context.Context, req admission.Request) admission.Response {
+	clusterSetName := req.Name
+
+	clusters, err := w.clusterClient.ClusterV1().ManagedClusters().List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", clusterSetLabel, clusterSetName),
+	})
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(clusters.Items) == 0 {
+		return admission.Allowed("")
+	}
+
+	names := make([]string, 0, len(clusters.Items))
+	for _, cluster := range clusters.Items {
+		names = append(names, cluster.Name)
+	}
+	return admission.Denied(fmt.Sprintf(
+		"ManagedClusterSet %q still has %d referring ManagedCluster(s): %v", clusterSetName, len(names), names))
+}
+
+func (w *ManagedClusterSetWebhook) validateMutation(req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	clusterSet := &clusterv1alpha1.ManagedClusterSet{}
+	if err := w.decoder.DecodeRaw(req.Object, clusterSet); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	oldClusterSet := &clusterv1alpha1.ManagedClusterSet{}
+	if err := w.decoder.DecodeRaw(req.OldObject, oldClusterSet); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// metadata.name is immutable on UPDATE (the apiserver guarantees req.Object and req.OldObject share the
+	// same name), so a rename can only happen through identityLabel; every other label is free to edit.
+	if oldClusterSet.Labels[identityLabel] != clusterSet.Labels[identityLabel] {
+		return admission.Denied(fmt.Sprintf("mutating the %q label on a ManagedClusterSet is not allowed", identityLabel))
+	}
+
+	return admission.Allowed("")
+}
@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var testScheme = func() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clusterv1alpha1.AddToScheme(scheme))
+	return scheme
+}()
+
+func TestValidateDelete(t *testing.T) {
+	cases := []struct {
+		name             string
+		existingClusters []*clusterv1.ManagedCluster
+		allowed          bool
+	}{
+		{
+			name:    "no referring clusters",
+			allowed: true,
+		},
+		{
+			name: "referring clusters block deletion",
+			existingClusters: []*clusterv1.ManagedCluster{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "cluster1",
+						Labels: map[string]string{clusterSetLabel: "mcs1"},
+					},
+				},
+			},
+			allowed: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			objects := make([]runtime.Object, 0, len(c.existingClusters))
+			for _, cluster := range c.existingClusters {
+				objects = append(objects, cluster)
+			}
+
+			clusterClient := clusterfake.NewSimpleClientset(objects...)
+
+			w := NewManagedClusterSetWebhook(clusterClient)
+			resp := w.validateDelete(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "mcs1",
+					Operation: admissionv1.Delete,
+				},
+			})
+
+			if resp.Allowed != c.allowed {
+				t.Errorf("expected allowed=%t, got %t: %v", c.allowed, resp.Allowed, resp.Result)
+			}
+		})
+	}
+}
+
+func TestValidateMutation(t *testing.T) {
+	cases := []struct {
+		name    string
+		old     *clusterv1alpha1.ManagedClusterSet
+		new     *clusterv1alpha1.ManagedClusterSet
+		allowed bool
+	}{
+		{
+			name:    "create is always allowed",
+			new:     newClusterSet("mcs1", nil),
+			allowed: true,
+		},
+		{
+			name:    "update with unchanged labels is allowed",
+			old:     newClusterSet("mcs1", map[string]string{"foo": "bar"}),
+			new:     newClusterSet("mcs1", map[string]string{"foo": "bar"}),
+			allowed: true,
+		},
+		{
+			name:    "update that edits a non-identity label is allowed",
+			old:     newClusterSet("mcs1", map[string]string{"foo": "bar"}),
+			new:     newClusterSet("mcs1", map[string]string{"foo": "baz"}),
+			allowed: true,
+		},
+		{
+			name:    "update that mutates the identity label is denied",
+			old:     newClusterSet("mcs1", map[string]string{identityLabel: "mcs1"}),
+			new:     newClusterSet("mcs1", map[string]string{identityLabel: "mcs2"}),
+			allowed: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := &ManagedClusterSetWebhook{decoder: admission.NewDecoder(testScheme)}
+
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: mustMarshal(t, c.new)},
+			}}
+			if c.old != nil {
+				req.Operation = admissionv1.Update
+				req.OldObject = runtime.RawExtension{Raw: mustMarshal(t, c.old)}
+			}
+
+			resp := w.validateMutation(req)
+			if resp.Allowed != c.allowed {
+				t.Errorf("expected allowed=%t, got %t: %v", c.allowed, resp.Allowed, resp.Result)
+			}
+		})
+	}
+}
+
+func newClusterSet(name string, labels map[string]string) *clusterv1alpha1.ManagedClusterSet {
+	return &clusterv1alpha1.ManagedClusterSet{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func mustMarshal(t *testing.T, obj *clusterv1alpha1.ManagedClusterSet) []byte {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	return data
+}